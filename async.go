@@ -0,0 +1,153 @@
+package littlealbert
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/api/trace"
+)
+
+// Async wraps a child Node whose real work may take longer than a single
+// tick. On the first Tick it kicks off the child's Tick in a goroutine,
+// using the span captured at kick-off time, and immediately returns
+// Running so sibling Nodes keep ticking at the parent's rate. Subsequent
+// Ticks continue to return Running until the goroutine completes, at
+// which point the cached Result and error are returned once and the
+// wrapper resets to accept the next invocation. If the context passed to
+// Tick is canceled while the child is in flight, the goroutine is
+// canceled and the cancellation error is returned.
+func Async(child Node) Node {
+	return &async{
+		name:  "Async",
+		child: child,
+	}
+}
+
+type async struct {
+	name  string
+	child Node
+
+	mu      sync.Mutex
+	running bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+	result  Result
+	err     error
+}
+
+func (a *async) Name() string {
+	return a.name
+}
+
+func (a *async) Children() []Node {
+	return []Node{a.child}
+}
+
+// Stop cancels the in-flight child's goroutine, if any. Async's
+// goroutine runs on a context rooted off context.Background() rather
+// than the context passed to Tick, so the only other way it learns of a
+// cancellation is ctx.Err() on a later Tick — which never comes if the
+// tree stops being ticked entirely, as happens when Run's own context is
+// canceled. Run calls Stop on every Stoppable Node for exactly that
+// case.
+func (a *async) Stop() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.running {
+		a.cancel()
+		a.running, a.cancel, a.done = false, nil, nil
+	}
+}
+
+func (a *async) Tick(ctx context.Context) (Result, error) {
+	ctx, span := childSpanFromContext(ctx, a.name)
+	defer span.End()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if ctx.Err() != nil {
+		if a.running {
+			a.cancel()
+			a.running, a.cancel, a.done = false, nil, nil
+		}
+
+		recordTick(ctx, span, Failure, ctx.Err(), tickAttrs("async", a.name, Failure))
+
+		return Failure, ctx.Err()
+	}
+
+	if !a.running {
+		runCtx := trace.ContextWithSpan(context.Background(), trace.SpanFromContext(ctx))
+		runCtx, cancel := context.WithCancel(runCtx)
+		done := make(chan struct{})
+
+		a.running, a.cancel, a.done = true, cancel, done
+
+		go func() {
+			defer close(done)
+
+			result, err := a.child.Tick(runCtx)
+
+			a.mu.Lock()
+			a.result, a.err = result, err
+			a.mu.Unlock()
+		}()
+	}
+
+	select {
+	case <-a.done:
+		result, err := a.result, a.err
+		a.running, a.cancel, a.done = false, nil, nil
+
+		recordTick(ctx, span, result, err, tickAttrs("async", a.name, result))
+
+		return result, err
+	default:
+		recordTick(ctx, span, Running, nil, tickAttrs("async", a.name, Running))
+
+		return Running, nil
+	}
+}
+
+// Sync wraps a child Node that internally spawns its own work, ensuring
+// that only one Tick of that child is ever in flight at a time by
+// serializing calls with a mutex. This is the inverse of Async: where
+// Async lets a slow child run in the background, Sync guards a child that
+// already manages its own concurrency from being ticked concurrently by,
+// for example, sibling branches of a Parallel node.
+func Sync(child Node) Node {
+	return &syncNode{
+		name:  "Sync",
+		child: child,
+	}
+}
+
+type syncNode struct {
+	name  string
+	child Node
+	mu    sync.Mutex
+}
+
+func (s *syncNode) Name() string {
+	return s.name
+}
+
+func (s *syncNode) Children() []Node {
+	return []Node{s.child}
+}
+
+func (s *syncNode) Tick(ctx context.Context) (Result, error) {
+	ctx, span := childSpanFromContext(ctx, s.name)
+	defer span.End()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, err := s.child.Tick(ctx)
+
+	recordTick(ctx, span, result, err, tickAttrs("sync", s.name, result))
+
+	return result, err
+}
@@ -0,0 +1,157 @@
+package littlealbert_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"littlealbert"
+)
+
+func Test_Async_RunsInBackground(t *testing.T) {
+	release := make(chan struct{})
+	var started sync.WaitGroup
+	started.Add(1)
+
+	slow := littlealbert.Task("slow", func(_ context.Context) (littlealbert.Result, error) {
+		started.Done()
+		<-release
+		return littlealbert.Success, nil
+	})
+
+	node := littlealbert.Async(slow)
+	ctx := context.Background()
+
+	result, err := node.Tick(ctx)
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Running, result)
+
+	started.Wait()
+
+	result, err = node.Tick(ctx)
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Running, result)
+
+	close(release)
+
+	require.Eventually(t, func() bool {
+		result, err = node.Tick(ctx)
+		return err == nil && result == littlealbert.Success
+	}, time.Second, time.Millisecond)
+}
+
+func Test_Async_CancelInFlight(t *testing.T) {
+	canceled := make(chan struct{})
+
+	slow := littlealbert.Task("slow", func(ctx context.Context) (littlealbert.Result, error) {
+		<-ctx.Done()
+		close(canceled)
+		return littlealbert.Invalid, ctx.Err()
+	})
+
+	node := littlealbert.Async(slow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	result, err := node.Tick(ctx)
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Running, result)
+
+	cancel()
+
+	result, err = node.Tick(ctx)
+	require.Error(t, err)
+	require.Equal(t, littlealbert.Failure, result)
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("expected in-flight child to be canceled")
+	}
+}
+
+func Test_Async_StopCancelsInFlightChildOnRunCancellation(t *testing.T) {
+	started := make(chan struct{})
+	canceled := make(chan struct{})
+
+	slow := littlealbert.Task("slow", func(ctx context.Context) (littlealbert.Result, error) {
+		close(started)
+		<-ctx.Done()
+		close(canceled)
+		return littlealbert.Invalid, ctx.Err()
+	})
+
+	tree := littlealbert.Async(slow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go littlealbert.Run(ctx, tree)
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("expected the async child to start")
+	}
+
+	cancel()
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("expected Run's cancellation to stop the in-flight async child")
+	}
+}
+
+func Test_Sync_SerializesConcurrentTicks(t *testing.T) {
+	var inFlight int32
+	var mu sync.Mutex
+	var maxObserved int
+
+	child := littlealbert.Task("guarded", func(_ context.Context) (littlealbert.Result, error) {
+		mu.Lock()
+		inFlight++
+		if int(inFlight) > maxObserved {
+			maxObserved = int(inFlight)
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		return littlealbert.Success, nil
+	})
+
+	node := littlealbert.Sync(child)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := node.Tick(context.Background())
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 1, maxObserved)
+}
+
+func Test_Sync_PropagatesError(t *testing.T) {
+	failure := errors.New("boom")
+	child := littlealbert.Task("erroring", func(_ context.Context) (littlealbert.Result, error) {
+		return littlealbert.Invalid, failure
+	})
+
+	node := littlealbert.Sync(child)
+
+	result, err := node.Tick(context.Background())
+	require.Equal(t, failure, err)
+	require.Equal(t, littlealbert.Invalid, result)
+}
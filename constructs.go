@@ -3,8 +3,8 @@ package littlealbert
 import "context"
 
 // Noop is a dummy task that always returns a Success result.
-var Noop = Task("Success Noop", func(_ context.Context) Result {
-	return Success
+var Noop = Task("Success Noop", func(_ context.Context) (Result, error) {
+	return Success, nil
 })
 
 // Label decorates a provided Node with provided Name.
@@ -12,51 +12,111 @@ func Label(name string, node Node) Node {
 	return Decorator(name, node, nil)
 }
 
+// ErrorPolicy determines how a Decorator responds to a non-nil error
+// returned by its child Node.
+type ErrorPolicy int
+
+const (
+	// ErrorAsFailure treats a child error as though the child had
+	// instead returned a Failure Result, clearing the error so the
+	// tree continues ticking normally.
+	ErrorAsFailure ErrorPolicy = iota
+	// ErrorIgnore discards the error entirely and proceeds using only
+	// the Result the child returned.
+	ErrorIgnore
+	// ErrorAbort immediately propagates the error up the tree,
+	// short-circuiting the decorator's usual behavior.
+	ErrorAbort
+)
+
+// resolveErrorPolicy returns the first policy provided, defaulting to
+// ErrorAsFailure when none is given.
+func resolveErrorPolicy(policy []ErrorPolicy) ErrorPolicy {
+	if len(policy) == 0 {
+		return ErrorAsFailure
+	}
+
+	return policy[0]
+}
+
 // RunUntilSuccess will run the underlying child Node until it returns
-// a Successful Result effectively ignoring any Failures..
-func RunUntilSuccess(child Node) Node {
+// a Successful Result effectively ignoring any Failures. policy controls
+// how a child error is handled and defaults to ErrorAsFailure.
+func RunUntilSuccess(child Node, policy ...ErrorPolicy) Node {
+	p := resolveErrorPolicy(policy)
+
 	return Decorator(
 		"Run until successful",
 		child,
-		func(_ context.Context, result Result) Result {
+		func(_ context.Context, result Result, err error) (Result, error) {
+			if err != nil {
+				switch p {
+				case ErrorAbort:
+					return result, err
+				case ErrorIgnore:
+					err = nil
+				default:
+					result, err = Failure, nil
+				}
+			}
+
 			if result == Success {
-				return Success
+				return Success, nil
 			}
 
-			return Running
+			return Running, nil
 		},
 	)
 }
 
 // RunUntilFailure will run the underlying child Node until it returns
-// a Failure Result effectively ignoring any Successes.
-func RunUntilFailure(child Node) Node {
+// a Failure Result effectively ignoring any Successes. policy controls
+// how a child error is handled and defaults to ErrorAsFailure.
+func RunUntilFailure(child Node, policy ...ErrorPolicy) Node {
+	p := resolveErrorPolicy(policy)
+
 	return Decorator(
 		"Run until failure",
 		child,
-		func(_ context.Context, status Result) Result {
+		func(_ context.Context, status Result, err error) (Result, error) {
+			if err != nil {
+				switch p {
+				case ErrorAbort:
+					return status, err
+				case ErrorIgnore:
+					err = nil
+				default:
+					status, err = Failure, nil
+				}
+			}
+
 			if status == Failure {
-				return Failure
+				return Failure, nil
 			}
 
-			return Running
+			return Running, nil
 		},
 	)
 }
 
-// Invert inverts the Result returned by the child Node.
+// Invert inverts the Result returned by the child Node. A non-nil error
+// is passed through unchanged.
 func Invert(child Node) Node {
 	return Decorator(
 		"Invert result",
 		child,
-		func(_ context.Context, result Result) Result {
+		func(_ context.Context, result Result, err error) (Result, error) {
+			if err != nil {
+				return result, err
+			}
+
 			switch result {
 			case Success:
-				return Failure
+				return Failure, nil
 			case Failure:
-				return Success
+				return Success, nil
 			default:
-				return result
+				return result, nil
 			}
 		},
 	)
@@ -0,0 +1,97 @@
+package littlealbert
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// FileSource is a Source that loads its subtree definition from a file on
+// disk, handing the file's raw bytes to the provided decode function,
+// and reloads whenever the file is written. decode is responsible for
+// turning those bytes — JSON, YAML, or any other format the caller
+// chooses — into a Node; FileSource only owns reading the file and
+// watching it for changes.
+//
+// The containing directory, rather than the file itself, is watched so
+// that the common "write a new file and rename it over the old one"
+// deploy pattern is picked up even though it replaces the file's inode.
+func FileSource(path string, decode func([]byte) (Node, error)) Source {
+	return &fileSource{
+		path:   path,
+		decode: decode,
+	}
+}
+
+type fileSource struct {
+	path   string
+	decode func([]byte) (Node, error)
+}
+
+func (s *fileSource) Load(_ context.Context) (Node, error) {
+	data, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decode(data)
+}
+
+func (s *fileSource) Watch(ctx context.Context) (<-chan Node, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := watcher.Add(filepath.Dir(s.path)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	out := make(chan Node)
+
+	go func() {
+		defer close(out)
+		defer watcher.Close()
+
+		target := filepath.Clean(s.path)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if filepath.Clean(event.Name) != target {
+					continue
+				}
+
+				if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+
+				node, err := s.Load(ctx)
+				if err != nil {
+					continue
+				}
+
+				select {
+				case out <- node:
+				case <-ctx.Done():
+					return
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,92 @@
+package littlealbert_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"littlealbert"
+)
+
+type fileConfig struct {
+	Result string `json:"result"`
+}
+
+func decodeFileConfig(data []byte) (littlealbert.Node, error) {
+	var cfg fileConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+
+	result := littlealbert.Failure
+	if cfg.Result == "success" {
+		result = littlealbert.Success
+	}
+
+	return littlealbert.Task("configured", func(_ context.Context) (littlealbert.Result, error) {
+		return result, nil
+	}), nil
+}
+
+func Test_FileSource_ReloadsOnWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "littlealbert-file-source")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"result":"success"}`), 0644))
+
+	watched := littlealbert.Watched("config", littlealbert.FileSource(path, decodeFileConfig))
+
+	result, err := watched.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"result":"failure"}`), 0644))
+
+	require.Eventually(t, func() bool {
+		result, err := watched.Tick(context.Background())
+		return err == nil && result == littlealbert.Failure
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// Test_FileSource_SurvivesPerTickContextCancellation reproduces the
+// pattern Run actually drives Watched with: each tick gets its own
+// context.WithTimeout, canceled the instant that Tick call returns. A
+// Watch loop rooted on the ctx that happened to trigger it would be
+// canceled within microseconds of starting, so hot-reload would never
+// fire in practice even though a test that only ever called Tick with an
+// uncancelled context.Background() would never catch it.
+func Test_FileSource_SurvivesPerTickContextCancellation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "littlealbert-file-source")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"result":"success"}`), 0644))
+
+	watched := littlealbert.Watched("config", littlealbert.FileSource(path, decodeFileConfig))
+
+	runCtx := context.Background()
+
+	tickCtx, cancel := context.WithTimeout(runCtx, time.Second)
+	result, err := watched.Tick(tickCtx)
+	cancel()
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"result":"failure"}`), 0644))
+
+	require.Eventually(t, func() bool {
+		tickCtx, cancel := context.WithTimeout(runCtx, time.Second)
+		defer cancel()
+
+		result, err := watched.Tick(tickCtx)
+		return err == nil && result == littlealbert.Failure
+	}, 5*time.Second, 10*time.Millisecond)
+}
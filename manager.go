@@ -0,0 +1,204 @@
+package littlealbert
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	managerInitialBackoff = 100 * time.Millisecond
+	managerMaxBackoff     = 30 * time.Second
+	managerHealthyAfter   = time.Minute
+)
+
+// TreeStatus is a point-in-time snapshot of one tree supervised by a
+// Manager.
+type TreeStatus struct {
+	// Name is the name the tree was Added under.
+	Name string
+	// Running reports whether the tree is still being ticked. A tree
+	// stops being supervised once it returns Success or is Stopped.
+	Running bool
+	// Restarts counts how many times the tree has been restarted after
+	// a Failure, an error, or a panic inside Tick.
+	Restarts int
+	// LastResult is the Result returned by the tree's most recent Run.
+	LastResult Result
+	// LastError is the error, if any, returned or recovered from the
+	// tree's most recent Run.
+	LastError error
+}
+
+// Manager runs an arbitrary number of Behavior Trees concurrently,
+// restarting any tree that fails, errors, or panics under an exponential
+// backoff: starting at 100ms, doubling on every consecutive restart, and
+// capped at 30s. A tree that runs healthily for more than a minute
+// resets its backoff back to the initial 100ms. A Manager is safe for
+// concurrent use.
+type Manager struct {
+	ctx context.Context
+
+	mu    sync.Mutex
+	trees map[string]*managedTree
+}
+
+type managedTree struct {
+	name   string
+	tree   Node
+	opts   []RunOption
+	cancel context.CancelFunc
+
+	mu         sync.Mutex
+	running    bool
+	restarts   int
+	lastResult Result
+	lastErr    error
+}
+
+// NewManager returns a Manager that supervises trees until ctx is
+// canceled, at which point every supervised tree is stopped.
+func NewManager(ctx context.Context) *Manager {
+	return &Manager{
+		ctx:   ctx,
+		trees: make(map[string]*managedTree),
+	}
+}
+
+// Add begins supervising tree under the provided name, ticking it via
+// Run with the given RunOptions. If a tree is already registered under
+// name, it is stopped and replaced.
+func (m *Manager) Add(name string, tree Node, opts ...RunOption) {
+	m.Stop(name)
+
+	treeCtx, cancel := context.WithCancel(m.ctx)
+
+	mt := &managedTree{
+		name:    name,
+		tree:    tree,
+		opts:    opts,
+		cancel:  cancel,
+		running: true,
+	}
+
+	m.mu.Lock()
+	old, replaced := m.trees[name]
+	m.trees[name] = mt
+	m.mu.Unlock()
+
+	if replaced {
+		old.cancel()
+	}
+
+	go m.supervise(treeCtx, mt)
+}
+
+// Stop cancels and deregisters the tree registered under name. It is a
+// no-op if no tree is registered under that name.
+func (m *Manager) Stop(name string) {
+	m.mu.Lock()
+	mt, ok := m.trees[name]
+	if ok {
+		delete(m.trees, name)
+	}
+	m.mu.Unlock()
+
+	if ok {
+		mt.cancel()
+	}
+}
+
+// Status returns a snapshot of every tree the Manager is supervising,
+// sorted by name.
+func (m *Manager) Status() []TreeStatus {
+	m.mu.Lock()
+	trees := make([]*managedTree, 0, len(m.trees))
+	for _, mt := range m.trees {
+		trees = append(trees, mt)
+	}
+	m.mu.Unlock()
+
+	sort.Slice(trees, func(i, j int) bool {
+		return trees[i].name < trees[j].name
+	})
+
+	statuses := make([]TreeStatus, 0, len(trees))
+	for _, mt := range trees {
+		mt.mu.Lock()
+		statuses = append(statuses, TreeStatus{
+			Name:       mt.name,
+			Running:    mt.running,
+			Restarts:   mt.restarts,
+			LastResult: mt.lastResult,
+			LastError:  mt.lastErr,
+		})
+		mt.mu.Unlock()
+	}
+
+	return statuses
+}
+
+// supervise runs mt.tree to completion via Run, restarting it under an
+// exponential backoff whenever it fails, errors, or panics, until ctx is
+// canceled or the tree returns Success.
+func (m *Manager) supervise(ctx context.Context, mt *managedTree) {
+	backoff := managerInitialBackoff
+
+	defer func() {
+		mt.mu.Lock()
+		mt.running = false
+		mt.mu.Unlock()
+	}()
+
+	for {
+		start := time.Now()
+		result, err := m.tick(ctx, mt)
+
+		mt.mu.Lock()
+		mt.lastResult, mt.lastErr = result, err
+		mt.mu.Unlock()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err == nil && result == Success {
+			return
+		}
+
+		if time.Since(start) > managerHealthyAfter {
+			backoff = managerInitialBackoff
+		}
+
+		mt.mu.Lock()
+		mt.restarts++
+		mt.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > managerMaxBackoff {
+			backoff = managerMaxBackoff
+		}
+	}
+}
+
+// tick runs mt.tree once to completion via Run, converting a panic
+// inside Tick into an error so the supervision loop can restart it like
+// any other failure.
+func (m *Manager) tick(ctx context.Context, mt *managedTree) (result Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = Failure
+			err = fmt.Errorf("littlealbert: tree %q panicked: %v", mt.name, r)
+		}
+	}()
+
+	return Run(ctx, mt.tree, mt.opts...)
+}
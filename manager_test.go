@@ -0,0 +1,87 @@
+package littlealbert_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"littlealbert"
+)
+
+func Test_Manager_RestartsFailingTree(t *testing.T) {
+	var attempts int32
+
+	tree := littlealbert.Task("flaky", func(_ context.Context) (littlealbert.Result, error) {
+		atomic.AddInt32(&attempts, 1)
+		return littlealbert.Failure, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	manager := littlealbert.NewManager(ctx)
+	manager.Add("flaky", tree)
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&attempts) >= 3
+	}, time.Second, time.Millisecond)
+
+	statuses := manager.Status()
+	require.Len(t, statuses, 1)
+	require.Equal(t, "flaky", statuses[0].Name)
+	require.True(t, statuses[0].Running)
+	require.GreaterOrEqual(t, statuses[0].Restarts, 2)
+}
+
+func Test_Manager_StopsSupervisingOnSuccess(t *testing.T) {
+	tree := littlealbert.Task("done", func(_ context.Context) (littlealbert.Result, error) {
+		return littlealbert.Success, nil
+	})
+
+	manager := littlealbert.NewManager(context.Background())
+	manager.Add("done", tree)
+
+	require.Eventually(t, func() bool {
+		statuses := manager.Status()
+		return len(statuses) == 1 && !statuses[0].Running
+	}, time.Second, time.Millisecond)
+
+	statuses := manager.Status()
+	require.Equal(t, littlealbert.Success, statuses[0].LastResult)
+	require.NoError(t, statuses[0].LastError)
+}
+
+func Test_Manager_RecoversPanic(t *testing.T) {
+	tree := littlealbert.Task("panicky", func(_ context.Context) (littlealbert.Result, error) {
+		panic("boom")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	manager := littlealbert.NewManager(ctx)
+	manager.Add("panicky", tree)
+
+	require.Eventually(t, func() bool {
+		statuses := manager.Status()
+		return len(statuses) == 1 && statuses[0].LastError != nil
+	}, time.Second, time.Millisecond)
+
+	statuses := manager.Status()
+	require.True(t, statuses[0].Running)
+	require.Contains(t, statuses[0].LastError.Error(), "boom")
+}
+
+func Test_Manager_Stop(t *testing.T) {
+	tree := littlealbert.Task("stoppable", func(_ context.Context) (littlealbert.Result, error) {
+		return littlealbert.Failure, nil
+	})
+
+	manager := littlealbert.NewManager(context.Background())
+	manager.Add("stoppable", tree)
+	manager.Stop("stoppable")
+
+	require.Empty(t, manager.Status())
+}
@@ -0,0 +1,255 @@
+package littlealbert
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// TickID identifies one Run invocation of a Behavior Tree, not a single
+// root tick: a Node such as MemSequence that resumes at the child it was
+// last Running in has to recognize the next tick as a continuation of
+// the same traversal, not a fresh one. Stateful Nodes such as
+// MemSequence, MemFallback, and Memorize key their memory by TickID so
+// that the same tree can be ticked concurrently by more than one caller
+// (for example two independent Run loops) without their progress
+// bleeding into one another. Run mints one TickID before its tick loop
+// starts and reuses it across every iteration of that loop.
+type TickID uint64
+
+var tickIDCounter uint64
+
+// NewTickID returns a TickID that has not been returned before.
+func NewTickID() TickID {
+	return TickID(atomic.AddUint64(&tickIDCounter, 1))
+}
+
+type tickIDKey struct{}
+
+// WithTickID returns a copy of ctx carrying the provided TickID.
+func WithTickID(ctx context.Context, id TickID) context.Context {
+	return context.WithValue(ctx, tickIDKey{}, id)
+}
+
+// TickIDFromContext returns the TickID carried by ctx, if any.
+func TickIDFromContext(ctx context.Context) (TickID, bool) {
+	id, ok := ctx.Value(tickIDKey{}).(TickID)
+	return id, ok
+}
+
+// MemSequence is the memory-backed counterpart to Sequence. Sequence is
+// "reactive": it always re-ticks its children from index 0, recheck
+// cheap preconditions on every tick. MemSequence is "sequential": it
+// resumes at the child that most recently returned Running, trusting
+// that preconditions earlier in the tick are still satisfied. Memory is
+// keyed by the TickID carried on the context, so a single MemSequence
+// can be shared by more than one ticker without cross-talk, and is
+// cleared for a given TickID whenever the sequence returns a
+// non-Running Result.
+//
+// Each child of a Parallel Node ticks concurrently under the same
+// TickID, so a MemSequence used as more than one child of the same
+// Parallel needs its own instance per child; sharing a single instance
+// across Parallel's children is not supported.
+func MemSequence(children ...Node) Node {
+	return &memSequence{
+		children: children,
+		index:    make(map[TickID]int),
+	}
+}
+
+type memSequence struct {
+	children []Node
+
+	mu    sync.Mutex
+	index map[TickID]int
+}
+
+func (s *memSequence) Children() []Node {
+	return s.children
+}
+
+func (s *memSequence) Tick(ctx context.Context) (Result, error) {
+	ctx, span := childSpanFromContext(ctx, "mem_sequence")
+	defer span.End()
+
+	id, _ := TickIDFromContext(ctx)
+
+	s.mu.Lock()
+	start := s.index[id]
+	s.mu.Unlock()
+
+	for i := start; i < len(s.children); i++ {
+		result, err := s.children[i].Tick(ctx)
+		if err != nil {
+			recordTick(ctx, span, result, err, tickAttrs("mem_sequence", "", result))
+			return result, err
+		}
+
+		if result == Running {
+			s.mu.Lock()
+			s.index[id] = i
+			s.mu.Unlock()
+
+			recordTick(ctx, span, result, nil, tickAttrs("mem_sequence", "", result))
+
+			return Running, nil
+		}
+
+		if result != Success {
+			s.mu.Lock()
+			delete(s.index, id)
+			s.mu.Unlock()
+
+			recordTick(ctx, span, result, nil, tickAttrs("mem_sequence", "", result))
+
+			return result, nil
+		}
+	}
+
+	s.mu.Lock()
+	delete(s.index, id)
+	s.mu.Unlock()
+
+	recordTick(ctx, span, Success, nil, tickAttrs("mem_sequence", "", Success))
+
+	return Success, nil
+}
+
+// MemFallback is the memory-backed counterpart to Fallback, resuming at
+// the child that most recently returned Running instead of re-ticking
+// every earlier sibling. See MemSequence for the full rationale, the
+// TickID-keyed memory, and the Parallel caveat; MemFallback clears its
+// memory for a given TickID whenever it returns a non-Running Result.
+func MemFallback(children ...Node) Node {
+	return &memFallback{
+		children: children,
+		index:    make(map[TickID]int),
+	}
+}
+
+type memFallback struct {
+	children []Node
+
+	mu    sync.Mutex
+	index map[TickID]int
+}
+
+func (f *memFallback) Children() []Node {
+	return f.children
+}
+
+func (f *memFallback) Tick(ctx context.Context) (Result, error) {
+	ctx, span := childSpanFromContext(ctx, "mem_fallback")
+	defer span.End()
+
+	id, _ := TickIDFromContext(ctx)
+
+	f.mu.Lock()
+	start := f.index[id]
+	f.mu.Unlock()
+
+	for i := start; i < len(f.children); i++ {
+		result, err := f.children[i].Tick(ctx)
+		if err != nil {
+			recordTick(ctx, span, result, err, tickAttrs("mem_fallback", "", result))
+			return result, err
+		}
+
+		if result == Running {
+			f.mu.Lock()
+			f.index[id] = i
+			f.mu.Unlock()
+
+			recordTick(ctx, span, result, nil, tickAttrs("mem_fallback", "", result))
+
+			return Running, nil
+		}
+
+		if result == Success {
+			f.mu.Lock()
+			delete(f.index, id)
+			f.mu.Unlock()
+
+			recordTick(ctx, span, result, nil, tickAttrs("mem_fallback", "", result))
+
+			return Success, nil
+		}
+	}
+
+	f.mu.Lock()
+	delete(f.index, id)
+	f.mu.Unlock()
+
+	recordTick(ctx, span, Failure, nil, tickAttrs("mem_fallback", "", Failure))
+
+	return Failure, nil
+}
+
+// tickResult caches what a memoized child returned for a single TickID.
+type tickResult struct {
+	result Result
+	err    error
+}
+
+// Memorize wraps a child Node and caches the Result (and error) it
+// produces for the current TickID, so a child reached more than once
+// during the same root tick — for example because it is shared by more
+// than one parent — is only actually ticked the first time. The cache
+// holds a single TickID's worth of memory at a time, so it is safe to
+// share across concurrent tickers without growing unbounded.
+func Memorize(child Node) Node {
+	return &memorize{
+		name:  "Memorize",
+		child: child,
+	}
+}
+
+type memorize struct {
+	name  string
+	child Node
+
+	mu     sync.Mutex
+	id     TickID
+	hasID  bool
+	cached tickResult
+}
+
+func (m *memorize) Name() string {
+	return m.name
+}
+
+func (m *memorize) Children() []Node {
+	return []Node{m.child}
+}
+
+func (m *memorize) Tick(ctx context.Context) (Result, error) {
+	ctx, span := childSpanFromContext(ctx, m.name)
+	defer span.End()
+
+	id, ok := TickIDFromContext(ctx)
+
+	if ok {
+		m.mu.Lock()
+		cached, hit := m.cached, m.hasID && m.id == id
+		m.mu.Unlock()
+
+		if hit {
+			recordTick(ctx, span, cached.result, cached.err, tickAttrs("memorize", m.name, cached.result))
+
+			return cached.result, cached.err
+		}
+	}
+
+	result, err := m.child.Tick(ctx)
+
+	if ok {
+		m.mu.Lock()
+		m.id, m.hasID, m.cached = id, true, tickResult{result, err}
+		m.mu.Unlock()
+	}
+
+	recordTick(ctx, span, result, err, tickAttrs("memorize", m.name, result))
+
+	return result, err
+}
@@ -0,0 +1,169 @@
+package littlealbert_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"littlealbert"
+)
+
+func Test_MemSequence_ResumesAtRunningChild(t *testing.T) {
+	var touchedFirst, touchedSecond int
+
+	first := littlealbert.Task("first", func(_ context.Context) (littlealbert.Result, error) {
+		touchedFirst++
+		return littlealbert.Success, nil
+	})
+
+	var once bool
+	second := littlealbert.Task("second", func(_ context.Context) (littlealbert.Result, error) {
+		touchedSecond++
+		if once {
+			return littlealbert.Success, nil
+		}
+		once = true
+		return littlealbert.Running, nil
+	})
+
+	seq := littlealbert.MemSequence(first, second)
+
+	ctx := littlealbert.WithTickID(context.Background(), littlealbert.NewTickID())
+
+	result, err := seq.Tick(ctx)
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Running, result)
+	require.Equal(t, 1, touchedFirst)
+	require.Equal(t, 1, touchedSecond)
+
+	result, err = seq.Tick(ctx)
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+	require.Equal(t, 1, touchedFirst, "first child should not be re-ticked while resuming at second")
+	require.Equal(t, 2, touchedSecond)
+}
+
+func Test_MemSequence_NoCrossTalkBetweenTickIDs(t *testing.T) {
+	var calls int
+
+	child := littlealbert.Task("running-once", func(_ context.Context) (littlealbert.Result, error) {
+		calls++
+		return littlealbert.Running, nil
+	})
+
+	seq := littlealbert.MemSequence(child)
+
+	ctxA := littlealbert.WithTickID(context.Background(), littlealbert.NewTickID())
+	ctxB := littlealbert.WithTickID(context.Background(), littlealbert.NewTickID())
+
+	resultA, err := seq.Tick(ctxA)
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Running, resultA)
+
+	resultB, err := seq.Tick(ctxB)
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Running, resultB)
+
+	require.Equal(t, 2, calls)
+}
+
+func Test_MemFallback_ResumesAtRunningChild(t *testing.T) {
+	var touchedFirst, touchedSecond int
+
+	first := littlealbert.Task("first", func(_ context.Context) (littlealbert.Result, error) {
+		touchedFirst++
+		return littlealbert.Failure, nil
+	})
+
+	var once bool
+	second := littlealbert.Task("second", func(_ context.Context) (littlealbert.Result, error) {
+		touchedSecond++
+		if once {
+			return littlealbert.Success, nil
+		}
+		once = true
+		return littlealbert.Running, nil
+	})
+
+	fb := littlealbert.MemFallback(first, second)
+
+	ctx := littlealbert.WithTickID(context.Background(), littlealbert.NewTickID())
+
+	result, err := fb.Tick(ctx)
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Running, result)
+	require.Equal(t, 1, touchedFirst)
+	require.Equal(t, 1, touchedSecond)
+
+	result, err = fb.Tick(ctx)
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+	require.Equal(t, 1, touchedFirst, "first child should not be re-ticked while resuming at second")
+	require.Equal(t, 2, touchedSecond)
+}
+
+func Test_Memorize_CachesWithinTickID(t *testing.T) {
+	var calls int
+
+	child := littlealbert.Task("expensive", func(_ context.Context) (littlealbert.Result, error) {
+		calls++
+		return littlealbert.Success, nil
+	})
+
+	memo := littlealbert.Memorize(child)
+
+	ctx := littlealbert.WithTickID(context.Background(), littlealbert.NewTickID())
+
+	result, err := memo.Tick(ctx)
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+
+	result, err = memo.Tick(ctx)
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+
+	require.Equal(t, 1, calls)
+
+	next := littlealbert.WithTickID(context.Background(), littlealbert.NewTickID())
+	result, err = memo.Tick(next)
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+	require.Equal(t, 2, calls)
+}
+
+// Test_MemSequence_ResumesAcrossRunTicks drives a MemSequence through
+// Run across two separate root ticks, reproducing the only way this
+// library is actually meant to be used. MemSequence's own tests only
+// ever build a single TickID-bearing context and Tick it directly
+// twice, which proves MemSequence is correct in isolation but can't
+// catch Run minting a fresh TickID every loop iteration — that would
+// make MemSequence indistinguishable from plain Sequence in practice.
+func Test_MemSequence_ResumesAcrossRunTicks(t *testing.T) {
+	var touchedFirst, touchedSecond int32
+
+	first := littlealbert.Task("first", func(_ context.Context) (littlealbert.Result, error) {
+		atomic.AddInt32(&touchedFirst, 1)
+		return littlealbert.Success, nil
+	})
+
+	var once bool
+	second := littlealbert.Task("second", func(_ context.Context) (littlealbert.Result, error) {
+		atomic.AddInt32(&touchedSecond, 1)
+		if once {
+			return littlealbert.Success, nil
+		}
+		once = true
+		return littlealbert.Running, nil
+	})
+
+	tree := littlealbert.MemSequence(first, second)
+
+	result, err := littlealbert.Run(context.Background(), tree, littlealbert.WithTickRate(10*time.Millisecond))
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+
+	require.EqualValues(t, 1, atomic.LoadInt32(&touchedFirst), "first should not be re-ticked once MemSequence resumed at second on the next root tick")
+	require.EqualValues(t, 2, atomic.LoadInt32(&touchedSecond))
+}
@@ -5,7 +5,7 @@ import (
 	"sync"
 	"sync/atomic"
 
-	"github.com/opentracing/opentracing-go/log"
+	"go.opentelemetry.io/otel/label"
 )
 
 const maxNodeConcurrency = 10
@@ -44,9 +44,11 @@ func (r Result) String() string {
 }
 
 // Node defines the minimum interface necessary to execute a Node
-// within the context of a Behavior Tree.
+// within the context of a Behavior Tree. The returned error, when
+// non-nil, short-circuits any composite Node executing it and is
+// propagated up the tree to Run.
 type Node interface {
-	Tick(context.Context) Result
+	Tick(context.Context) (Result, error)
 }
 
 // NamedNode extends the minimum Node interface to allow
@@ -66,6 +68,18 @@ type ParentNode interface {
 	Children() []Node
 }
 
+// Stoppable is implemented by Nodes that hold long-lived background
+// state needing an explicit chance to unwind when a tree is torn down
+// outside of the normal tick loop — for example Async's in-flight
+// goroutine, which is deliberately rooted off context.Background() so a
+// single per-tick timeout doesn't cancel a slow child early. Run calls
+// Stop on every Stoppable Node in the tree once its governing context is
+// canceled, since nothing else ever gives that goroutine a chance to
+// observe the cancellation.
+type Stoppable interface {
+	Stop()
+}
+
 // Conditional is any function which, given a context, returns
 // a boolean value.
 func Conditional(name string, cond func(context.Context) bool) Node {
@@ -84,30 +98,24 @@ func (c conditional) Name() string {
 	return c.name
 }
 
-func (c conditional) Tick(ctx context.Context) Result {
-	span, ctx := childSpanFromContext(ctx, c.name)
-	defer span.Finish()
-
-	span.LogFields(
-		log.String("node_type", "conditional"),
-	)
+func (c conditional) Tick(ctx context.Context) (Result, error) {
+	ctx, span := childSpanFromContext(ctx, c.name)
+	defer span.End()
 
+	result := Failure
 	if c.cond(ctx) {
-		span.LogFields(
-			log.String("node_result", Success.String()),
-		)
-		return Success
+		result = Success
 	}
 
-	span.LogFields(
-		log.String("node_result", Failure.String()),
-	)
-	return Failure
+	recordTick(ctx, span, result, nil, tickAttrs("conditional", c.name, result))
+
+	return result, nil
 }
 
 // Task is any childless function which, given a context,
-// returns a Behavior Tree Result.
-func Task(name string, t func(context.Context) Result) Node {
+// returns a Behavior Tree Result or an error if it was unable
+// to complete its work.
+func Task(name string, t func(context.Context) (Result, error)) Node {
 	return &task{
 		name: name,
 		t:    t,
@@ -116,7 +124,7 @@ func Task(name string, t func(context.Context) Result) Node {
 
 type task struct {
 	name string
-	t    func(context.Context) Result
+	t    func(context.Context) (Result, error)
 }
 
 func (t task) Name() string {
@@ -125,21 +133,15 @@ func (t task) Name() string {
 
 // Tick turns the childless Task function into a valid
 // Behavior Tree Node.
-func (t task) Tick(ctx context.Context) Result {
-	span, ctx := childSpanFromContext(ctx, t.name)
-	defer span.Finish()
+func (t task) Tick(ctx context.Context) (Result, error) {
+	ctx, span := childSpanFromContext(ctx, t.name)
+	defer span.End()
 
-	span.LogFields(
-		log.String("node_type", "task"),
-	)
+	result, err := t.t(ctx)
 
-	result := t.t(ctx)
+	recordTick(ctx, span, result, err, tickAttrs("task", t.name, result))
 
-	span.LogFields(
-		log.String("node_result", result.String()),
-	)
-
-	return result
+	return result, err
 }
 
 // Sequence nodes route their execution ticks to their
@@ -161,29 +163,26 @@ func (s sequence) Children() []Node {
 	return s.children
 }
 
-func (s sequence) Tick(ctx context.Context) Result {
-	span, ctx := childSpanFromContext(ctx, "sequence")
-	defer span.Finish()
-
-	span.LogFields(
-		log.String("node_type", "sequence"),
-	)
+func (s sequence) Tick(ctx context.Context) (Result, error) {
+	ctx, span := childSpanFromContext(ctx, "sequence")
+	defer span.End()
 
 	for _, node := range s.children {
-		if result := node.Tick(ctx); result != Success {
-			span.LogFields(
-				log.String("node_result", result.String()),
-			)
+		result, err := node.Tick(ctx)
+		if err != nil {
+			recordTick(ctx, span, result, err, tickAttrs("sequence", "", result))
+			return result, err
+		}
 
-			return result
+		if result != Success {
+			recordTick(ctx, span, result, nil, tickAttrs("sequence", "", result))
+			return result, nil
 		}
 	}
 
-	span.LogFields(
-		log.String("node_result", Success.String()),
-	)
+	recordTick(ctx, span, Success, nil, tickAttrs("sequence", "", Success))
 
-	return Success
+	return Success, nil
 }
 
 // Fallback Nodes route their execution ticks to their chldren
@@ -205,34 +204,140 @@ func (f fallback) Children() []Node {
 	return f.children
 }
 
-func (f fallback) Tick(ctx context.Context) Result {
-	span, ctx := childSpanFromContext(ctx, "fallback")
-	defer span.Finish()
-
-	span.LogFields(
-		log.String("node_type", "fallback"),
-	)
+func (f fallback) Tick(ctx context.Context) (Result, error) {
+	ctx, span := childSpanFromContext(ctx, "fallback")
+	defer span.End()
 
 	for _, node := range f.children {
-		if result := node.Tick(ctx); result == Success || result == Running {
-			span.LogFields(
-				log.String("node_result", result.String()),
-			)
+		result, err := node.Tick(ctx)
+		if err != nil {
+			recordTick(ctx, span, result, err, tickAttrs("fallback", "", result))
+			return result, err
+		}
+
+		if result == Success || result == Running {
+			recordTick(ctx, span, result, nil, tickAttrs("fallback", "", result))
+			return result, nil
+		}
+	}
+
+	recordTick(ctx, span, Failure, nil, tickAttrs("fallback", "", Failure))
+
+	return Failure, nil
+}
+
+// All Nodes tick every child from left to right, regardless of whether an
+// earlier child returned Failure, and is the non-short-circuiting
+// counterpart to Sequence. An error from any child still short-circuits
+// the remaining children and is propagated immediately. All returns
+// Failure if any child failed, Running if none failed but at least one
+// is still Running, and Success only if every child succeeded.
+func All(children ...Node) Node {
+	return &all{
+		children: children,
+	}
+}
+
+type all struct {
+	children []Node
+}
+
+func (a all) Children() []Node {
+	return a.children
+}
+
+func (a all) Tick(ctx context.Context) (Result, error) {
+	ctx, span := childSpanFromContext(ctx, "all")
+	defer span.End()
+
+	var sawFailure, sawRunning bool
+
+	for _, node := range a.children {
+		result, err := node.Tick(ctx)
+		if err != nil {
+			recordTick(ctx, span, result, err, tickAttrs("all", "", result))
+			return result, err
+		}
+
+		switch result {
+		case Failure:
+			sawFailure = true
+		case Running:
+			sawRunning = true
+		}
+	}
+
+	result := Success
+	switch {
+	case sawFailure:
+		result = Failure
+	case sawRunning:
+		result = Running
+	}
+
+	recordTick(ctx, span, result, nil, tickAttrs("all", "", result))
+
+	return result, nil
+}
+
+// Any Nodes tick every child from left to right, regardless of whether an
+// earlier child returned Success, and is the non-short-circuiting
+// counterpart to Fallback. An error from any child still short-circuits
+// the remaining children and is propagated immediately. Any returns
+// Success if any child succeeded, Running if none succeeded but at least
+// one is still Running, and Failure only if every child failed.
+func Any(children ...Node) Node {
+	return &anyNode{
+		children: children,
+	}
+}
+
+type anyNode struct {
+	children []Node
+}
+
+func (a anyNode) Children() []Node {
+	return a.children
+}
+
+func (a anyNode) Tick(ctx context.Context) (Result, error) {
+	ctx, span := childSpanFromContext(ctx, "any")
+	defer span.End()
 
-			return result
+	var sawSuccess, sawRunning bool
+
+	for _, node := range a.children {
+		result, err := node.Tick(ctx)
+		if err != nil {
+			recordTick(ctx, span, result, err, tickAttrs("any", "", result))
+			return result, err
+		}
+
+		switch result {
+		case Success:
+			sawSuccess = true
+		case Running:
+			sawRunning = true
 		}
 	}
 
-	span.LogFields(
-		log.String("node_result", Failure.String()),
-	)
+	result := Failure
+	switch {
+	case sawSuccess:
+		result = Success
+	case sawRunning:
+		result = Running
+	}
+
+	recordTick(ctx, span, result, nil, tickAttrs("any", "", result))
 
-	return Failure
+	return result, nil
 }
 
-// Decorator Nodes are control flow nodes that manipulate the Result returned
-// by their single child Node.
-func Decorator(name string, child Node, modifier func(context.Context, Result) Result) Node {
+// Decorator Nodes are control flow nodes that manipulate the Result and
+// error returned by their single child Node. A nil modifier passes the
+// child's Result and error through unchanged.
+func Decorator(name string, child Node, modifier func(context.Context, Result, error) (Result, error)) Node {
 	return &decorator{
 		name:  name,
 		child: child,
@@ -243,7 +348,7 @@ func Decorator(name string, child Node, modifier func(context.Context, Result) R
 type decorator struct {
 	name  string
 	child Node
-	fn    func(context.Context, Result) Result
+	fn    func(context.Context, Result, error) (Result, error)
 }
 
 func (d decorator) Name() string {
@@ -254,29 +359,21 @@ func (d decorator) Children() []Node {
 	return []Node{d.child}
 }
 
-func (d decorator) Tick(ctx context.Context) Result {
-	span, ctx := childSpanFromContext(ctx, d.name)
-	defer span.Finish()
-
-	span.LogFields(
-		log.String("node_type", "decorator"),
-	)
+func (d decorator) Tick(ctx context.Context) (Result, error) {
+	ctx, span := childSpanFromContext(ctx, d.name)
+	defer span.End()
 
-	result := d.child.Tick(ctx)
-
-	span.LogFields(
-		log.String("wrapped_result", result.String()),
-	)
+	wrapped, err := d.child.Tick(ctx)
 
+	result := wrapped
 	if d.fn != nil {
-		result = d.fn(ctx, result)
+		result, err = d.fn(ctx, wrapped, err)
 	}
 
-	span.LogFields(
-		log.String("node_result", result.String()),
-	)
+	attrs := tickAttrs("decorator", d.name, result, label.String("wrapped_result", wrapped.String()))
+	recordTick(ctx, span, result, err, attrs)
 
-	return result
+	return result, err
 }
 
 // Parallel nodes route their execution tick to all children nodes every time
@@ -284,7 +381,11 @@ func (d decorator) Tick(ctx context.Context) Result {
 // the number of Success Results returned by the child Tick calls is equal to
 // or exceeds the Threshold value set in thresh. Conversely, the Parallel node
 // returns Failure should the number of Failure results returned by children
-// Nodes exceeds len(children) - thresh.
+// Nodes exceeds len(children) - thresh. Once any child returns an error,
+// Parallel stops starting children that haven't been ticked yet and
+// propagates that error; children already ticking concurrently at that
+// point still run to completion, since Parallel has no way to abort a
+// Tick already in flight.
 func Parallel(threshold int, children ...Node) Node {
 	return &parallel{
 		children: children,
@@ -301,18 +402,20 @@ func (p parallel) Children() []Node {
 	return p.children
 }
 
-func (p parallel) Tick(ctx context.Context) (res Result) {
+func (p parallel) Tick(ctx context.Context) (res Result, err error) {
 	var successes, failures uint64
+	var errMu sync.Mutex
+	var aborted int32
 
-	span, ctx := childSpanFromContext(ctx, "parallel")
+	ctx, span := childSpanFromContext(ctx, "parallel")
 	defer func() {
-		span.LogFields(
-			log.String("node_type", "parallel"),
-			log.String("node_result", res.String()),
-			log.Int("parallel_success_count", int(successes)),
-			log.Int("parallel_failure_count", int(failures)),
+		attrs := tickAttrs(
+			"parallel", "", res,
+			label.Int("parallel_success_count", int(successes)),
+			label.Int("parallel_failure_count", int(failures)),
 		)
-		span.Finish()
+		recordTick(ctx, span, res, err, attrs)
+		span.End()
 	}()
 
 	children := make(chan Node, len(p.children))
@@ -329,7 +432,24 @@ func (p parallel) Tick(ctx context.Context) (res Result) {
 		go func() {
 			defer wg.Done()
 			for node := range children {
-				switch result := node.Tick(ctx); result {
+				if atomic.LoadInt32(&aborted) != 0 {
+					continue
+				}
+
+				result, tickErr := node.Tick(ctx)
+				if tickErr != nil {
+					errMu.Lock()
+					if err == nil {
+						err = tickErr
+					}
+					errMu.Unlock()
+
+					atomic.StoreInt32(&aborted, 1)
+
+					continue
+				}
+
+				switch result {
 				case Success:
 					atomic.AddUint64(&successes, 1)
 				case Failure:
@@ -341,15 +461,19 @@ func (p parallel) Tick(ctx context.Context) (res Result) {
 
 	wg.Wait()
 
+	if err != nil {
+		return Failure, err
+	}
+
 	if successes >= p.thresh {
-		return Success
+		return Success, nil
 	}
 
 	if failures >= (uint64(len(p.children)) - p.thresh) {
-		return Failure
+		return Failure, nil
 	}
 
-	return Running
+	return Running, nil
 }
 
 // Dynamic nodes are nodes whose children cannot be defined at compile time.
@@ -376,23 +500,21 @@ func (d dynamic) Children() []Node {
 }
 
 func (d dynamic) construct(ctx context.Context) Node {
-	span, ctx := childSpanFromContext(ctx, d.name+"::constructor")
-	defer span.Finish()
+	ctx, span := childSpanFromContext(ctx, d.name+"::constructor")
+	defer span.End()
 
-	span.LogFields(
-		log.String("dynamic_step", "constructor"),
-	)
+	span.SetAttributes(label.String("dynamic_step", "constructor"))
 
 	return d.cons(ctx)
 }
 
-func (d dynamic) Tick(ctx context.Context) Result {
-	span, ctx := childSpanFromContext(ctx, d.name)
-	defer span.Finish()
+func (d dynamic) Tick(ctx context.Context) (Result, error) {
+	ctx, span := childSpanFromContext(ctx, d.name)
+	defer span.End()
+
+	result, err := d.construct(ctx).Tick(ctx)
 
-	span.LogFields(
-		log.String("node_type", "dynamic"),
-	)
+	recordTick(ctx, span, result, err, tickAttrs("dynamic", d.name, result))
 
-	return d.construct(ctx).Tick(ctx)
+	return result, err
 }
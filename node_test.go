@@ -2,20 +2,36 @@ package littlealbert_test
 
 import (
 	"context"
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"littlealbert"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func Test_Task_Simple(t *testing.T) {
-	simple := func(ctx context.Context) littlealbert.Result {
-		return littlealbert.Success
+	simple := func(ctx context.Context) (littlealbert.Result, error) {
+		return littlealbert.Success, nil
 	}
 
-	task := littlealbert.Task(simple)
+	task := littlealbert.Task("simple", simple)
 
-	require.Equal(t, littlealbert.Success, task.Tick(context.Background()))
+	result, err := task.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+}
+
+func Test_Task_Error(t *testing.T) {
+	failure := errors.New("boom")
+	task := littlealbert.Task("erroring", func(ctx context.Context) (littlealbert.Result, error) {
+		return littlealbert.Invalid, failure
+	})
+
+	result, err := task.Tick(context.Background())
+	require.Equal(t, failure, err)
+	require.Equal(t, littlealbert.Invalid, result)
 }
 
 type MaxTick struct {
@@ -23,14 +39,14 @@ type MaxTick struct {
 	max     int
 }
 
-func (t *MaxTick) Tick(_ context.Context) littlealbert.Result {
+func (t *MaxTick) Tick(_ context.Context) (littlealbert.Result, error) {
 	if t.counter >= t.max {
-		return littlealbert.Success
+		return littlealbert.Success, nil
 	}
 
 	t.counter++
 
-	return littlealbert.Running
+	return littlealbert.Running, nil
 }
 
 func Test_Task_Complex(t *testing.T) {
@@ -44,7 +60,10 @@ func Test_Task_Complex(t *testing.T) {
 
 		ctx := context.Background()
 
-		if ticker.Tick(ctx) == littlealbert.Success {
+		result, err := ticker.Tick(ctx)
+		require.NoError(t, err)
+
+		if result == littlealbert.Success {
 			break
 		}
 	}
@@ -53,93 +72,332 @@ func Test_Task_Complex(t *testing.T) {
 }
 
 func Test_Conditional(t *testing.T) {
-	cond := littlealbert.Conditional(func(_ context.Context) bool {
+	cond := littlealbert.Conditional("false", func(_ context.Context) bool {
 		return false
 	})
 
-	require.Equal(t, littlealbert.Failure, cond.Tick(context.Background()))
+	result, err := cond.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Failure, result)
 
-	cond = littlealbert.Conditional(func(_ context.Context) bool {
+	cond = littlealbert.Conditional("true", func(_ context.Context) bool {
 		return true
 	})
 
-	require.Equal(t, littlealbert.Success, cond.Tick(context.Background()))
+	result, err = cond.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
 }
 
 func Test_Empty_Sequence(t *testing.T) {
 	seq := littlealbert.Sequence()
 
-	require.Equal(t, littlealbert.Success, seq.Tick(context.Background()))
+	result, err := seq.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
 }
 
 func Test_All_Succeed_Sequence(t *testing.T) {
-	success := func(_ context.Context) littlealbert.Result {
-		return littlealbert.Success
+	success := func(_ context.Context) (littlealbert.Result, error) {
+		return littlealbert.Success, nil
 	}
 
 	seq := littlealbert.Sequence(
-		littlealbert.Task(success),
-		littlealbert.Task(success),
-		littlealbert.Task(success),
-		littlealbert.Task(success),
-		littlealbert.Task(success),
-		littlealbert.Task(success),
+		littlealbert.Task("a", success),
+		littlealbert.Task("b", success),
+		littlealbert.Task("c", success),
+		littlealbert.Task("d", success),
+		littlealbert.Task("e", success),
+		littlealbert.Task("f", success),
 	)
 
-	require.Equal(t, littlealbert.Success, seq.Tick(context.Background()))
+	result, err := seq.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
 }
 
 func Test_One_Failure_Sequence(t *testing.T) {
-	success := func(_ context.Context) littlealbert.Result {
-		return littlealbert.Success
+	success := func(_ context.Context) (littlealbert.Result, error) {
+		return littlealbert.Success, nil
+	}
+
+	seq := littlealbert.Sequence(
+		littlealbert.Task("a", success),
+		littlealbert.Task("b", success),
+		littlealbert.Task("c", success),
+		littlealbert.Task("d", func(_ context.Context) (littlealbert.Result, error) {
+			return littlealbert.Failure, nil
+		}),
+		littlealbert.Task("e", success),
+		littlealbert.Task("f", success),
+	)
+
+	result, err := seq.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Failure, result)
+}
+
+func Test_One_Error_Sequence(t *testing.T) {
+	failure := errors.New("boom")
+
+	var touched bool
+	success := func(_ context.Context) (littlealbert.Result, error) {
+		touched = true
+		return littlealbert.Success, nil
 	}
 
 	seq := littlealbert.Sequence(
-		littlealbert.Task(success),
-		littlealbert.Task(success),
-		littlealbert.Task(success),
-		littlealbert.Task(func(_ context.Context) littlealbert.Result {
-			return littlealbert.Failure
+		littlealbert.Task("a", success),
+		littlealbert.Task("erroring", func(_ context.Context) (littlealbert.Result, error) {
+			return littlealbert.Invalid, failure
 		}),
-		littlealbert.Task(success),
-		littlealbert.Task(success),
+		littlealbert.Task("never reached", success),
 	)
 
-	require.Equal(t, littlealbert.Failure, seq.Tick(context.Background()))
+	result, err := seq.Tick(context.Background())
+	require.Equal(t, failure, err)
+	require.Equal(t, littlealbert.Invalid, result)
+	assert.True(t, touched, "the first child should still have run")
 }
 
 func Test_Running_Sequence(t *testing.T) {
 	var touch int
 	var once bool
 
-	success := func(_ context.Context) littlealbert.Result {
+	success := func(_ context.Context) (littlealbert.Result, error) {
 		touch++
 
-		return littlealbert.Success
+		return littlealbert.Success, nil
 	}
 
 	seq := littlealbert.Sequence(
-		littlealbert.Task(success),
-		littlealbert.Task(success),
-		littlealbert.Task(success),
-		littlealbert.Task(func(_ context.Context) littlealbert.Result {
+		littlealbert.Task("a", success),
+		littlealbert.Task("b", success),
+		littlealbert.Task("c", success),
+		littlealbert.Task("d", func(_ context.Context) (littlealbert.Result, error) {
 			if once {
-				return littlealbert.Success
+				return littlealbert.Success, nil
 			}
 
 			once = true
 
-			return littlealbert.Running
+			return littlealbert.Running, nil
 		}),
-		littlealbert.Task(success),
-		littlealbert.Task(success),
+		littlealbert.Task("e", success),
+		littlealbert.Task("f", success),
 	)
 
-	require.Equal(t, littlealbert.Running, seq.Tick(context.Background()))
+	result, err := seq.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Running, result)
 	assert.Equal(t, 3, touch)
 
 	touch = 0
-	require.Equal(t, littlealbert.Success, seq.Tick(context.Background()))
+	result, err = seq.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
 	assert.Equal(t, 5, touch)
 
 }
+
+func Test_All_Succeeds(t *testing.T) {
+	success := func(_ context.Context) (littlealbert.Result, error) {
+		return littlealbert.Success, nil
+	}
+
+	all := littlealbert.All(
+		littlealbert.Task("a", success),
+		littlealbert.Task("b", success),
+		littlealbert.Task("c", success),
+	)
+
+	result, err := all.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+}
+
+func Test_All_TicksEveryChildDespiteFailure(t *testing.T) {
+	var touched []string
+
+	track := func(name string, result littlealbert.Result) func(context.Context) (littlealbert.Result, error) {
+		return func(_ context.Context) (littlealbert.Result, error) {
+			touched = append(touched, name)
+			return result, nil
+		}
+	}
+
+	all := littlealbert.All(
+		littlealbert.Task("a", track("a", littlealbert.Success)),
+		littlealbert.Task("b", track("b", littlealbert.Failure)),
+		littlealbert.Task("c", track("c", littlealbert.Success)),
+	)
+
+	result, err := all.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Failure, result)
+	assert.Equal(t, []string{"a", "b", "c"}, touched)
+}
+
+func Test_All_RunningWithoutFailure(t *testing.T) {
+	success := func(_ context.Context) (littlealbert.Result, error) {
+		return littlealbert.Success, nil
+	}
+
+	all := littlealbert.All(
+		littlealbert.Task("a", success),
+		littlealbert.Task("b", func(_ context.Context) (littlealbert.Result, error) {
+			return littlealbert.Running, nil
+		}),
+		littlealbert.Task("c", success),
+	)
+
+	result, err := all.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Running, result)
+}
+
+func Test_All_ErrorShortCircuits(t *testing.T) {
+	failure := errors.New("boom")
+
+	var touched bool
+	all := littlealbert.All(
+		littlealbert.Task("erroring", func(_ context.Context) (littlealbert.Result, error) {
+			return littlealbert.Invalid, failure
+		}),
+		littlealbert.Task("never reached", func(_ context.Context) (littlealbert.Result, error) {
+			touched = true
+			return littlealbert.Success, nil
+		}),
+	)
+
+	result, err := all.Tick(context.Background())
+	require.Equal(t, failure, err)
+	require.Equal(t, littlealbert.Invalid, result)
+	assert.False(t, touched, "a child after the error should never run")
+}
+
+func Test_Any_Fails(t *testing.T) {
+	failure := func(_ context.Context) (littlealbert.Result, error) {
+		return littlealbert.Failure, nil
+	}
+
+	any := littlealbert.Any(
+		littlealbert.Task("a", failure),
+		littlealbert.Task("b", failure),
+		littlealbert.Task("c", failure),
+	)
+
+	result, err := any.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Failure, result)
+}
+
+func Test_Any_TicksEveryChildDespiteSuccess(t *testing.T) {
+	var touched []string
+
+	track := func(name string, result littlealbert.Result) func(context.Context) (littlealbert.Result, error) {
+		return func(_ context.Context) (littlealbert.Result, error) {
+			touched = append(touched, name)
+			return result, nil
+		}
+	}
+
+	any := littlealbert.Any(
+		littlealbert.Task("a", track("a", littlealbert.Failure)),
+		littlealbert.Task("b", track("b", littlealbert.Success)),
+		littlealbert.Task("c", track("c", littlealbert.Failure)),
+	)
+
+	result, err := any.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+	assert.Equal(t, []string{"a", "b", "c"}, touched)
+}
+
+func Test_Any_RunningWithoutSuccess(t *testing.T) {
+	failure := func(_ context.Context) (littlealbert.Result, error) {
+		return littlealbert.Failure, nil
+	}
+
+	any := littlealbert.Any(
+		littlealbert.Task("a", failure),
+		littlealbert.Task("b", func(_ context.Context) (littlealbert.Result, error) {
+			return littlealbert.Running, nil
+		}),
+		littlealbert.Task("c", failure),
+	)
+
+	result, err := any.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Running, result)
+}
+
+func Test_Any_ErrorShortCircuits(t *testing.T) {
+	failure := errors.New("boom")
+
+	var touched bool
+	any := littlealbert.Any(
+		littlealbert.Task("erroring", func(_ context.Context) (littlealbert.Result, error) {
+			return littlealbert.Invalid, failure
+		}),
+		littlealbert.Task("never reached", func(_ context.Context) (littlealbert.Result, error) {
+			touched = true
+			return littlealbert.Success, nil
+		}),
+	)
+
+	result, err := any.Tick(context.Background())
+	require.Equal(t, failure, err)
+	require.Equal(t, littlealbert.Invalid, result)
+	assert.False(t, touched, "a child after the error should never run")
+}
+
+func Test_Parallel_ShortCircuitsOnError(t *testing.T) {
+	failure := errors.New("boom")
+
+	release := make(chan struct{})
+	var touched int32
+
+	children := []littlealbert.Node{
+		littlealbert.Task("erroring", func(_ context.Context) (littlealbert.Result, error) {
+			return littlealbert.Invalid, failure
+		}),
+	}
+
+	for i := 0; i < 9; i++ {
+		children = append(children, littlealbert.Task("blocked", func(_ context.Context) (littlealbert.Result, error) {
+			<-release
+			return littlealbert.Success, nil
+		}))
+	}
+
+	children = append(children, littlealbert.Task("queued", func(_ context.Context) (littlealbert.Result, error) {
+		atomic.StoreInt32(&touched, 1)
+		return littlealbert.Success, nil
+	}))
+
+	par := littlealbert.Parallel(len(children), children...)
+
+	done := make(chan struct{})
+	var result littlealbert.Result
+	var err error
+	go func() {
+		result, err = par.Tick(context.Background())
+		close(done)
+	}()
+
+	require.Never(t, func() bool {
+		return atomic.LoadInt32(&touched) != 0
+	}, 100*time.Millisecond, 10*time.Millisecond, "the queued child should never have been ticked once an earlier child errored")
+
+	close(release)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected parallel.Tick to return once the blocked children were released")
+	}
+
+	require.Equal(t, failure, err)
+	require.Equal(t, littlealbert.Failure, result)
+}
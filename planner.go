@@ -0,0 +1,181 @@
+package littlealbert
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Condition is a pure predicate over whatever state a Planner is
+// reasoning about. Conditions are the vocabulary a Planner plans in: an
+// Action declares the Conditions it requires (Pre) and the Conditions it
+// establishes (Post), and a goal passed to Plan is itself a Condition.
+// Two Conditions are the same proposition if they share a Name; Holds is
+// never inspected by the planner itself; it only runs once Plan has
+// compiled the Condition into a Conditional Node.
+type Condition struct {
+	Name  string
+	Holds func(ctx context.Context) bool
+}
+
+func (c Condition) node() Node {
+	return Conditional(c.Name, c.Holds)
+}
+
+// Action is a single step a Planner may use to satisfy a Condition. Pre
+// lists the Conditions that must already hold before Do is expected to
+// succeed; Post lists the Conditions Do is expected to establish once it
+// succeeds. A Planner matches an unmet Condition to an Action by looking
+// for the Condition's Name among that Action's Post list — a simple
+// set-based match rather than a full STRIPS solver.
+type Action struct {
+	Name string
+	Pre  []Condition
+	Post []Condition
+	Do   func(ctx context.Context) Result
+}
+
+func (a Action) node() Node {
+	return Task(a.Name, func(ctx context.Context) (Result, error) {
+		return a.Do(ctx), nil
+	})
+}
+
+func (a Action) satisfies(goal Condition) bool {
+	for _, post := range a.Post {
+		if post.Name == goal.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Planner synthesises Behavior Trees out of a library of Actions using
+// the Planning-and-Acting-with-Behavior-Trees (PA-BT) approach: to
+// satisfy a Condition, find an Action whose postconditions match it and
+// build Fallback(condition, Sequence(subplans..., action)), recursively
+// expanding every one of that Action's unmet preconditions the same way.
+// The resulting Node re-checks the condition it was planned for on every
+// tick, so it only runs the expansion when the condition doesn't already
+// hold — the same reactive behavior as a hand-authored Fallback branch.
+// A Planner is safe for concurrent use.
+type Planner struct {
+	actions []Action
+
+	mu         sync.Mutex
+	conditions map[string]Condition
+}
+
+// NewPlanner returns a Planner that synthesises trees out of the given
+// Action library.
+func NewPlanner(actions ...Action) *Planner {
+	p := &Planner{
+		actions:    actions,
+		conditions: make(map[string]Condition),
+	}
+
+	for _, action := range actions {
+		for _, cond := range action.Pre {
+			p.conditions[cond.Name] = cond
+		}
+
+		for _, cond := range action.Post {
+			p.conditions[cond.Name] = cond
+		}
+	}
+
+	return p
+}
+
+// Plan synthesises a Node that satisfies goal, returning an error if no
+// Action in the library's postconditions can satisfy it (directly or
+// transitively through some chain of preconditions).
+func (p *Planner) Plan(goal Condition) (Node, error) {
+	p.mu.Lock()
+	p.conditions[goal.Name] = goal
+	p.mu.Unlock()
+
+	return p.expand(goal, map[string]bool{})
+}
+
+// Refine re-synthesises the subtree for the Condition named by
+// failedNode, which is expected to be the Conditional Node Plan compiled
+// that Condition into. Callers are expected to call Refine when a
+// planned Condition's Fallback branch fails, and graft the returned Node
+// in place of the one that failed. Refine returns an error if failedNode
+// doesn't name a Condition the Planner knows about, or if the Condition
+// can no longer be satisfied.
+func (p *Planner) Refine(ctx context.Context, failedNode Node) (Node, error) {
+	named, ok := failedNode.(NamedNode)
+	if !ok {
+		return nil, fmt.Errorf("littlealbert: planner: %T does not name the condition that failed", failedNode)
+	}
+
+	ctx, span := childSpanFromContext(ctx, "planner::refine")
+	defer span.End()
+
+	p.mu.Lock()
+	cond, ok := p.conditions[named.Name()]
+	p.mu.Unlock()
+
+	if !ok {
+		err := fmt.Errorf("littlealbert: planner: no known condition named %q", named.Name())
+		span.RecordError(ctx, err)
+		return nil, err
+	}
+
+	refined, err := p.expand(cond, map[string]bool{})
+	if err != nil {
+		span.RecordError(ctx, err)
+		return nil, err
+	}
+
+	span.AddEvent(ctx, "plan.refined")
+
+	return refined, nil
+}
+
+// expand builds Fallback(goal, Sequence(subplans..., action)) for the
+// Action that satisfies goal, recursively expanding goal's unmet
+// preconditions. planning tracks the Conditions already being expanded
+// on the current path so a cyclic set of preconditions is reported as an
+// error instead of recursing forever.
+func (p *Planner) expand(goal Condition, planning map[string]bool) (Node, error) {
+	if planning[goal.Name] {
+		return nil, fmt.Errorf("littlealbert: planner: cyclic dependency on condition %q", goal.Name)
+	}
+
+	action, ok := p.find(goal)
+	if !ok {
+		return nil, fmt.Errorf("littlealbert: planner: no action satisfies condition %q", goal.Name)
+	}
+
+	planning[goal.Name] = true
+	defer delete(planning, goal.Name)
+
+	steps := make([]Node, 0, len(action.Pre)+1)
+
+	for _, pre := range action.Pre {
+		subplan, err := p.expand(pre, planning)
+		if err != nil {
+			return nil, err
+		}
+
+		steps = append(steps, Fallback(pre.node(), subplan))
+	}
+
+	steps = append(steps, action.node())
+
+	return Fallback(goal.node(), Sequence(steps...)), nil
+}
+
+func (p *Planner) find(goal Condition) (Action, bool) {
+	for _, action := range p.actions {
+		if action.satisfies(goal) {
+			return action, true
+		}
+	}
+
+	return Action{}, false
+}
@@ -0,0 +1,159 @@
+package littlealbert_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"littlealbert"
+)
+
+func Test_Planner_PlansDirectAction(t *testing.T) {
+	var opened bool
+
+	doorOpen := littlealbert.Condition{
+		Name:  "door open",
+		Holds: func(_ context.Context) bool { return opened },
+	}
+
+	planner := littlealbert.NewPlanner(littlealbert.Action{
+		Name: "open door",
+		Post: []littlealbert.Condition{doorOpen},
+		Do: func(_ context.Context) littlealbert.Result {
+			opened = true
+			return littlealbert.Success
+		},
+	})
+
+	tree, err := planner.Plan(doorOpen)
+	require.NoError(t, err)
+
+	result, err := tree.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+	require.True(t, opened)
+}
+
+func Test_Planner_SkipsActionWhenGoalAlreadyHolds(t *testing.T) {
+	doorOpen := littlealbert.Condition{
+		Name:  "door open",
+		Holds: func(_ context.Context) bool { return true },
+	}
+
+	var ran bool
+	planner := littlealbert.NewPlanner(littlealbert.Action{
+		Name: "open door",
+		Post: []littlealbert.Condition{doorOpen},
+		Do: func(_ context.Context) littlealbert.Result {
+			ran = true
+			return littlealbert.Success
+		},
+	})
+
+	tree, err := planner.Plan(doorOpen)
+	require.NoError(t, err)
+
+	result, err := tree.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+	require.False(t, ran, "the action should not run when the goal already holds")
+}
+
+func Test_Planner_ExpandsUnmetPreconditions(t *testing.T) {
+	var haveKey, doorOpen bool
+
+	keyHeld := littlealbert.Condition{
+		Name:  "key held",
+		Holds: func(_ context.Context) bool { return haveKey },
+	}
+
+	open := littlealbert.Condition{
+		Name:  "door open",
+		Holds: func(_ context.Context) bool { return doorOpen },
+	}
+
+	planner := littlealbert.NewPlanner(
+		littlealbert.Action{
+			Name: "pick up key",
+			Post: []littlealbert.Condition{keyHeld},
+			Do: func(_ context.Context) littlealbert.Result {
+				haveKey = true
+				return littlealbert.Success
+			},
+		},
+		littlealbert.Action{
+			Name: "open door",
+			Pre:  []littlealbert.Condition{keyHeld},
+			Post: []littlealbert.Condition{open},
+			Do: func(_ context.Context) littlealbert.Result {
+				if !haveKey {
+					return littlealbert.Failure
+				}
+
+				doorOpen = true
+				return littlealbert.Success
+			},
+		},
+	)
+
+	tree, err := planner.Plan(open)
+	require.NoError(t, err)
+
+	result, err := tree.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+	require.True(t, haveKey)
+	require.True(t, doorOpen)
+}
+
+func Test_Planner_NoActionSatisfiesGoal(t *testing.T) {
+	planner := littlealbert.NewPlanner()
+
+	_, err := planner.Plan(littlealbert.Condition{
+		Name:  "unreachable",
+		Holds: func(_ context.Context) bool { return false },
+	})
+	require.Error(t, err)
+}
+
+func Test_Planner_Refine(t *testing.T) {
+	var haveKey bool
+
+	keyHeld := littlealbert.Condition{
+		Name:  "key held",
+		Holds: func(_ context.Context) bool { return haveKey },
+	}
+
+	planner := littlealbert.NewPlanner(littlealbert.Action{
+		Name: "pick up key",
+		Post: []littlealbert.Condition{keyHeld},
+		Do: func(_ context.Context) littlealbert.Result {
+			haveKey = true
+			return littlealbert.Success
+		},
+	})
+
+	tree, err := planner.Plan(keyHeld)
+	require.NoError(t, err)
+
+	failedCondition := littlealbert.Conditional(keyHeld.Name, keyHeld.Holds)
+
+	refined, err := planner.Refine(context.Background(), failedCondition)
+	require.NoError(t, err)
+
+	result, err := refined.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+	require.True(t, haveKey)
+
+	require.NotNil(t, tree)
+}
+
+func Test_Planner_RefineUnknownCondition(t *testing.T) {
+	planner := littlealbert.NewPlanner()
+
+	_, err := planner.Refine(context.Background(), littlealbert.Conditional("unknown", func(_ context.Context) bool {
+		return false
+	}))
+	require.Error(t, err)
+}
@@ -26,6 +26,10 @@ func p(node Node, tree tp.Tree) {
 		label = "Sequence"
 	case *fallback:
 		label = "Fallback"
+	case *all:
+		label = "All"
+	case *anyNode:
+		label = "Any"
 	case *parallel:
 		label = "Parallel"
 	case *decorator:
@@ -37,6 +41,18 @@ func p(node Node, tree tp.Tree) {
 		}
 	case *dynamic:
 		label = "Dynamic"
+	case *watched:
+		label = "Watched"
+	case *async:
+		label = "Async"
+	case *syncNode:
+		label = "Sync"
+	case *memSequence:
+		label = "MemSequence"
+	case *memFallback:
+		label = "MemFallback"
+	case *memorize:
+		label = "Memorize"
 	default:
 		label = "Unknown Node"
 	}
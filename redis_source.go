@@ -0,0 +1,90 @@
+package littlealbert
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-redis/redis/v7"
+)
+
+// RedisSource is a Source that loads its subtree definition from the
+// value of a Redis key, handing the value's raw bytes to the provided
+// decode function, and reloads whenever the key is written with SET.
+// decode is responsible for turning those bytes into a Node, the same as
+// in FileSource. Other commands that modify the key (APPEND, SETRANGE,
+// RENAME onto it, and the like) do not trigger a reload.
+//
+// Changes are detected with Redis keyspace notifications, so the server
+// must be configured with `notify-keyspace-events KEA` (or at least `K$`:
+// keyspace events for string commands) for Watch to observe updates; without that
+// configuration Load still works but Watch's channel will never receive.
+func RedisSource(client *redis.Client, key string, decode func([]byte) (Node, error)) Source {
+	return &redisSource{
+		client: client,
+		key:    key,
+		decode: decode,
+	}
+}
+
+type redisSource struct {
+	client *redis.Client
+	key    string
+	decode func([]byte) (Node, error)
+}
+
+func (s *redisSource) Load(ctx context.Context) (Node, error) {
+	data, err := s.client.WithContext(ctx).Get(s.key).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decode(data)
+}
+
+func (s *redisSource) Watch(ctx context.Context) (<-chan Node, error) {
+	pattern := fmt.Sprintf("__keyspace@*__:%s", s.key)
+
+	pubsub := s.client.WithContext(ctx).PSubscribe(pattern)
+
+	if _, err := pubsub.Receive(); err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	out := make(chan Node)
+
+	go func() {
+		defer close(out)
+		defer pubsub.Close()
+
+		msgs := pubsub.Channel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-msgs:
+				if !ok {
+					return
+				}
+
+				if msg.Payload != "set" {
+					continue
+				}
+
+				node, err := s.Load(ctx)
+				if err != nil {
+					continue
+				}
+
+				select {
+				case out <- node:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
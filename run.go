@@ -4,76 +4,109 @@ import (
 	"context"
 	"time"
 
-	opentracing "github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/log"
+	"go.opentelemetry.io/otel/api/trace"
 )
 
 var (
-	defaultTickRate    = 10 * time.Second
-	defaultTickTimeout = time.Second
-	defaultTracer      = opentracing.NoopTracer{}
+	defaultTickRate                            = 10 * time.Second
+	defaultTickTimeout                         = time.Second
+	defaultTracerProvider trace.TracerProvider = trace.NoopTracerProvider()
 )
 
 // RunConfiguration ...
 type RunConfiguration struct {
-	tickRate    time.Duration
-	tickTimeout time.Duration
-	tracer      opentracing.Tracer
+	tickRate       time.Duration
+	tickTimeout    time.Duration
+	tracerProvider trace.TracerProvider
 }
 
 func defaultRunConfig() *RunConfiguration {
 	return &RunConfiguration{
-		tickRate:    defaultTickRate,
-		tickTimeout: defaultTickTimeout,
-		tracer:      &defaultTracer,
+		tickRate:       defaultTickRate,
+		tickTimeout:    defaultTickTimeout,
+		tracerProvider: defaultTracerProvider,
 	}
 }
 
 // RunOption ...
 type RunOption func(config *RunConfiguration)
 
-func WithTracer(tracer opentracing.Tracer) RunOption {
+// WithTracerProvider installs the given OTel TracerProvider as the
+// source of spans for every Node ticked by this Run. Callers who have
+// not yet migrated their tracing backend off OpenTracing can adapt their
+// existing opentracing.Tracer with OpenTracingAdapter.
+func WithTracerProvider(tracerProvider trace.TracerProvider) RunOption {
 	return func(config *RunConfiguration) {
-		config.tracer = tracer
+		config.tracerProvider = tracerProvider
+	}
+}
+
+// WithTickRate overrides the interval Run waits between ticks while the
+// tree keeps returning Running. Defaults to 10 seconds.
+func WithTickRate(rate time.Duration) RunOption {
+	return func(config *RunConfiguration) {
+		config.tickRate = rate
 	}
 }
 
 // Run executes the provided Behavior Tree at the provided Tick Rate with
-// the specified per-Tick timeout and provided parent context until a non-Running
-// Result is returned.
-func Run(ctx context.Context, tree Node, opts ...RunOption) Result {
+// the specified per-Tick timeout and provided parent context until a
+// non-Running Result is returned or the tree returns an error, in which
+// case the error is returned to the caller immediately.
+func Run(ctx context.Context, tree Node, opts ...RunOption) (Result, error) {
 	config := defaultRunConfig()
 
 	for _, opt := range opts {
 		opt(config)
 	}
 
-	opentracing.SetGlobalTracer(config.tracer)
+	ctx = contextWithTracerProvider(ctx, config.tracerProvider)
+
+	tickID := NewTickID()
 
 	for {
 		tickCtx, cancel := context.WithTimeout(ctx, config.tickTimeout)
-		root := opentracing.StartSpan("littlealbert::root")
-		tickCtx = opentracing.ContextWithSpan(tickCtx, root)
+		tickCtx = WithTickID(tickCtx, tickID)
+		tickCtx, root := tracerFromContext(tickCtx).Start(tickCtx, "littlealbert::root")
 
-		result := tree.Tick(tickCtx)
+		result, err := tree.Tick(tickCtx)
 
 		cancel()
-		root.LogFields(
-			log.String("node_type", "root"),
-			log.String("node_result", result.String()),
-		)
 
-		root.Finish()
+		recordTick(tickCtx, root, result, err, tickAttrs("root", "", result))
+		root.End()
+
+		if err != nil {
+			return result, err
+		}
 
 		if result != Running {
-			return result
+			return result, nil
 		}
 
 		select {
 		case <-ctx.Done():
-			return Failure
+			stop(tree)
+			return Failure, ctx.Err()
 		case <-time.Tick(config.tickRate):
 			continue
 		}
 	}
 }
+
+// stop recursively calls Stop on every Stoppable Node in the tree
+// rooted at node. Run calls this once its governing context is
+// canceled, since a Node such as Async that deliberately detaches
+// long-lived background work from the per-tick context otherwise has no
+// other way to learn the tree is being torn down.
+func stop(node Node) {
+	if s, ok := node.(Stoppable); ok {
+		s.Stop()
+	}
+
+	if p, ok := node.(ParentNode); ok {
+		for _, child := range p.Children() {
+			stop(child)
+		}
+	}
+}
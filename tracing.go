@@ -2,23 +2,185 @@ package littlealbert
 
 import (
 	"context"
+	"time"
 
-	opentracing "github.com/opentracing/opentracing-go"
+	ot "github.com/opentracing/opentracing-go"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
 )
 
-var noop = opentracing.NoopTracer{}
+const tracerName = "littlealbert"
 
-func childSpanFromContext(ctx context.Context, operation string) (opentracing.Span, context.Context) {
-	span := opentracing.SpanFromContext(ctx)
-	var tracer opentracing.Tracer = &noop
+type tracerProviderKey struct{}
 
-	if span != nil {
-		tracer = span.Tracer()
+// contextWithTracerProvider returns a copy of ctx carrying tp, so every
+// span started beneath it uses tp rather than the process-global
+// TracerProvider. Run installs its configured TracerProvider this way
+// instead of calling the process-global SetTracerProvider, since a
+// Manager supervises many trees concurrently and a global install from
+// one tree's Run would stomp the provider every other tree's spans are
+// using.
+func contextWithTracerProvider(ctx context.Context, tp trace.TracerProvider) context.Context {
+	return context.WithValue(ctx, tracerProviderKey{}, tp)
+}
+
+// tracerFromContext returns the Tracer for whichever TracerProvider Run
+// installed on ctx, falling back to the process-global TracerProvider (a
+// noop implementation, by default) for a ctx that was never passed
+// through Run — for example in a test that Ticks a Node directly.
+func tracerFromContext(ctx context.Context) trace.Tracer {
+	if tp, ok := ctx.Value(tracerProviderKey{}).(trace.TracerProvider); ok {
+		return tp.Tracer(tracerName)
+	}
+
+	return global.Tracer(tracerName)
+}
+
+// childSpanFromContext starts a new span named "littlealbert::"+operation
+// as a child of whatever span (if any) is already active in ctx, using
+// the TracerProvider installed by Run (a noop TracerProvider unless
+// WithTracerProvider is given), or the process-global TracerProvider for
+// a ctx that was never passed through Run.
+func childSpanFromContext(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return tracerFromContext(ctx).Start(ctx, "littlealbert::"+operation)
+}
+
+// tickAttrs is the set of attributes every Node records on its span: its
+// type, its name when it has one, and the Result it produced. Composite
+// Nodes such as Parallel append their own attributes (e.g. success and
+// failure counters) alongside these.
+func tickAttrs(nodeType, name string, result Result, extra ...label.KeyValue) []label.KeyValue {
+	attrs := make([]label.KeyValue, 0, len(extra)+3)
+	attrs = append(attrs, label.String("node.type", nodeType))
+
+	if name != "" {
+		attrs = append(attrs, label.String("node.name", name))
+	}
+
+	attrs = append(attrs, label.String("node.result", result.String()))
+	attrs = append(attrs, extra...)
+
+	return attrs
+}
+
+// recordTick finishes a Node's instrumentation: it attaches the given
+// attributes to span and sets the span's status to mirror result and
+// err, exactly the way an OTel-instrumented call is expected to report
+// its outcome (Ok for Success, Error for Failure or a non-nil error,
+// Unset for Running).
+func recordTick(ctx context.Context, span trace.Span, result Result, err error, attrs []label.KeyValue) {
+	span.SetAttributes(attrs...)
+
+	if err != nil {
+		span.RecordError(ctx, err)
+		span.SetStatus(codes.Error, err.Error())
+		return
 	}
 
-	return opentracing.StartSpanFromContextWithTracer(
-		ctx,
-		tracer,
-		"littlealbert::"+operation,
-	)
+	switch result {
+	case Success:
+		span.SetStatus(codes.Ok, "")
+	case Failure:
+		span.SetStatus(codes.Error, "")
+	default:
+		span.SetStatus(codes.Unset, "")
+	}
+}
+
+// OpenTracingAdapter adapts an existing OpenTracing Tracer into an OTel
+// TracerProvider so callers who have not yet migrated their tracing
+// backend off OpenTracing can keep using it with WithTracerProvider.
+// Span attributes, status, and recorded errors are translated to
+// OpenTracing tags and log fields, and child spans are linked to their
+// parent via the standard OpenTracing child-of reference. This is an
+// opt-in compatibility shim, not a general-purpose bridge: spans created
+// through it do not carry a usable OTel SpanContext.
+func OpenTracingAdapter(tracer ot.Tracer) trace.TracerProvider {
+	return &otAdapterProvider{tracer: tracer}
+}
+
+type otAdapterProvider struct {
+	tracer ot.Tracer
+}
+
+func (p *otAdapterProvider) Tracer(_ string, _ ...trace.TracerOption) trace.Tracer {
+	return &otAdapterTracer{tracer: p.tracer}
+}
+
+type otAdapterTracer struct {
+	tracer ot.Tracer
+}
+
+func (t *otAdapterTracer) Start(ctx context.Context, name string, _ ...trace.SpanOption) (context.Context, trace.Span) {
+	var opts []ot.StartSpanOption
+	if parent := ot.SpanFromContext(ctx); parent != nil {
+		opts = append(opts, ot.ChildOf(parent.Context()))
+	}
+
+	span := t.tracer.StartSpan(name, opts...)
+
+	return ot.ContextWithSpan(ctx, span), &otAdapterSpan{tracer: t, span: span}
+}
+
+type otAdapterSpan struct {
+	tracer *otAdapterTracer
+	span   ot.Span
+}
+
+func (s *otAdapterSpan) Tracer() trace.Tracer {
+	return s.tracer
+}
+
+func (s *otAdapterSpan) End(...trace.SpanOption) {
+	s.span.Finish()
+}
+
+func (s *otAdapterSpan) AddEvent(_ context.Context, name string, attrs ...label.KeyValue) {
+	kvs := make([]interface{}, 0, len(attrs)*2+2)
+	kvs = append(kvs, "event", name)
+	for _, attr := range attrs {
+		kvs = append(kvs, string(attr.Key), attr.Value.AsInterface())
+	}
+	s.span.LogKV(kvs...)
+}
+
+func (s *otAdapterSpan) AddEventWithTimestamp(ctx context.Context, _ time.Time, name string, attrs ...label.KeyValue) {
+	s.AddEvent(ctx, name, attrs...)
+}
+
+func (s *otAdapterSpan) IsRecording() bool {
+	return true
+}
+
+func (s *otAdapterSpan) RecordError(_ context.Context, err error, _ ...trace.ErrorOption) {
+	s.span.SetTag("error", true)
+	s.span.LogKV("event", "error", "error.object", err.Error())
+}
+
+func (s *otAdapterSpan) SpanContext() trace.SpanContext {
+	return trace.EmptySpanContext()
+}
+
+func (s *otAdapterSpan) SetStatus(code codes.Code, msg string) {
+	if code == codes.Error {
+		s.span.SetTag("error", true)
+	}
+
+	s.span.SetTag("otel.status_code", code.String())
+
+	if msg != "" {
+		s.span.LogKV("otel.status_description", msg)
+	}
+}
+
+func (s *otAdapterSpan) SetName(name string) {
+	s.span.SetOperationName(name)
+}
+
+func (s *otAdapterSpan) SetAttributes(kv ...label.KeyValue) {
+	for _, attr := range kv {
+		s.span.SetTag(string(attr.Key), attr.Value.AsInterface())
+	}
 }
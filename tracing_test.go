@@ -0,0 +1,126 @@
+package littlealbert_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/require"
+	"littlealbert"
+)
+
+func Test_OpenTracingAdapter_RecordsSpans(t *testing.T) {
+	tracer := mocktracer.New()
+
+	tree := littlealbert.Sequence(
+		littlealbert.Task("a", func(_ context.Context) (littlealbert.Result, error) {
+			return littlealbert.Success, nil
+		}),
+	)
+
+	result, err := littlealbert.Run(
+		context.Background(),
+		tree,
+		littlealbert.WithTracerProvider(littlealbert.OpenTracingAdapter(tracer)),
+	)
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+
+	spans := tracer.FinishedSpans()
+	require.NotEmpty(t, spans)
+
+	var sawTask bool
+	for _, span := range spans {
+		if span.OperationName == "littlealbert::a" {
+			sawTask = true
+			require.Equal(t, "task", span.Tag("node.type"))
+			require.Equal(t, "success", span.Tag("node.result"))
+		}
+	}
+	require.True(t, sawTask, "expected a span for the task node")
+}
+
+func Test_OpenTracingAdapter_RecordsErrors(t *testing.T) {
+	tracer := mocktracer.New()
+
+	boom := errors.New("boom")
+	tree := littlealbert.Task("erroring", func(_ context.Context) (littlealbert.Result, error) {
+		return littlealbert.Invalid, boom
+	})
+
+	_, err := littlealbert.Run(
+		context.Background(),
+		tree,
+		littlealbert.WithTracerProvider(littlealbert.OpenTracingAdapter(tracer)),
+	)
+	require.Equal(t, boom, err)
+
+	var sawError bool
+	for _, span := range tracer.FinishedSpans() {
+		if span.OperationName == "littlealbert::erroring" {
+			sawError = true
+			require.Equal(t, true, span.Tag("error"))
+		}
+	}
+	require.True(t, sawError, "expected a span for the erroring task")
+}
+
+// Test_Run_ConcurrentTracerProvidersDoNotClobberEachOther drives two
+// trees through Run concurrently, each configured with its own
+// TracerProvider via WithTracerProvider, the way a Manager supervising
+// more than one tree does. Before the TracerProvider was threaded
+// through the context instead of installed with the process-global
+// SetTracerProvider, whichever Run happened to call SetTracerProvider
+// last would silently steal the other tree's spans.
+func Test_Run_ConcurrentTracerProvidersDoNotClobberEachOther(t *testing.T) {
+	tracerA := mocktracer.New()
+	tracerB := mocktracer.New()
+
+	release := make(chan struct{})
+
+	treeFor := func(name string) littlealbert.Node {
+		return littlealbert.Task(name, func(_ context.Context) (littlealbert.Result, error) {
+			<-release
+			return littlealbert.Success, nil
+		})
+	}
+
+	done := make(chan struct{}, 2)
+
+	go func() {
+		littlealbert.Run(context.Background(), treeFor("a"), littlealbert.WithTracerProvider(littlealbert.OpenTracingAdapter(tracerA)))
+		done <- struct{}{}
+	}()
+
+	go func() {
+		littlealbert.Run(context.Background(), treeFor("b"), littlealbert.WithTracerProvider(littlealbert.OpenTracingAdapter(tracerB)))
+		done <- struct{}{}
+	}()
+
+	close(release)
+	<-done
+	<-done
+
+	var sawA, sawB bool
+	for _, span := range tracerA.FinishedSpans() {
+		if span.OperationName == "littlealbert::a" {
+			sawA = true
+		}
+	}
+	for _, span := range tracerB.FinishedSpans() {
+		if span.OperationName == "littlealbert::b" {
+			sawB = true
+		}
+	}
+
+	require.True(t, sawA, "tracerA should have recorded tree a's span")
+	require.True(t, sawB, "tracerB should have recorded tree b's span")
+
+	for _, span := range tracerA.FinishedSpans() {
+		require.NotEqual(t, "littlealbert::b", span.OperationName, "tracerA should not have recorded tree b's span")
+	}
+	for _, span := range tracerB.FinishedSpans() {
+		require.NotEqual(t, "littlealbert::a", span.OperationName, "tracerB should not have recorded tree a's span")
+	}
+}
@@ -0,0 +1,146 @@
+package littlealbert
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Source supplies the subtree a Watched Node should currently be running
+// and notifies it when that definition changes. Load returns the Node
+// described by the source's current state. Watch returns a channel that
+// receives a freshly constructed Node every time the underlying
+// definition changes; the channel is closed once ctx is canceled.
+// Implementations are not expected to emit the initial definition on the
+// Watch channel, only subsequent changes — Watched always calls Load
+// first to establish the starting subtree.
+type Source interface {
+	Load(ctx context.Context) (Node, error)
+	Watch(ctx context.Context) (<-chan Node, error)
+}
+
+// Watched wraps a Source behind a Node that only rebuilds its cached
+// subtree when the Source reports a change, instead of reconstructing it
+// on every tick the way Dynamic does. This trades Dynamic's simplicity
+// for the ability to redeploy a subtree's behavior — by editing a file or
+// a Redis key, for example — without restarting the process. The cached
+// subtree is replaced atomically, so a Tick already in flight always runs
+// to completion against the subtree it started with and a concurrent
+// reload is never observed half-applied.
+func Watched(name string, src Source) Node {
+	return &watched{
+		name: name,
+		src:  src,
+	}
+}
+
+type watched struct {
+	name string
+	src  Source
+
+	startOnce sync.Once
+	startErr  error
+	tree      atomic.Value // Node
+	reloaded  int32        // set by reload(), consumed by the next Tick's span
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+func (w *watched) Name() string {
+	return w.name
+}
+
+func (w *watched) Children() []Node {
+	if node, ok := w.tree.Load().(Node); ok {
+		return []Node{node}
+	}
+
+	return nil
+}
+
+// start is called under sync.Once from the Tick that happens to trigger
+// it, so ctx is whatever per-tick context that caller supplied — through
+// Run, a context.WithTimeout good for a single tick that gets canceled
+// the instant Tick returns. Watch's goroutine has to keep running across
+// many such ticks, so it is rooted off a context.Background()-derived
+// context that *watched* owns and can cancel independently, the same
+// way Async detaches its in-flight goroutine from the per-tick context
+// it happened to start under.
+func (w *watched) start(ctx context.Context) error {
+	w.startOnce.Do(func() {
+		node, err := w.src.Load(ctx)
+		if err != nil {
+			w.startErr = err
+			return
+		}
+
+		w.tree.Store(node)
+
+		watchCtx, cancel := context.WithCancel(context.Background())
+
+		changes, err := w.src.Watch(watchCtx)
+		if err != nil {
+			cancel()
+			w.startErr = err
+			return
+		}
+
+		w.mu.Lock()
+		w.cancel = cancel
+		w.mu.Unlock()
+
+		go w.reload(changes)
+	})
+
+	return w.startErr
+}
+
+// Stop cancels the Source's Watch goroutine, if Watched has ever
+// started one. Watch runs on a context rooted off context.Background()
+// rather than the context passed to Tick, so teardown driven by a
+// canceled Run context would otherwise leak it — the fsnotify watch or
+// Redis subscription it holds open never gets closed. Run calls Stop on
+// every Stoppable Node for exactly that case, mirroring async.Stop().
+func (w *watched) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cancel != nil {
+		w.cancel()
+		w.cancel = nil
+	}
+}
+
+// reload consumes the Source's change channel, swapping in each new
+// subtree as it arrives. The tracing event for the swap is raised on the
+// next Tick rather than here, since a background goroutine has no span
+// of its own to attach it to.
+func (w *watched) reload(changes <-chan Node) {
+	for node := range changes {
+		w.tree.Store(node)
+		atomic.StoreInt32(&w.reloaded, 1)
+	}
+}
+
+func (w *watched) Tick(ctx context.Context) (Result, error) {
+	ctx, span := childSpanFromContext(ctx, w.name)
+	defer span.End()
+
+	if err := w.start(ctx); err != nil {
+		recordTick(ctx, span, Failure, err, tickAttrs("watched", w.name, Failure))
+		return Failure, err
+	}
+
+	if atomic.CompareAndSwapInt32(&w.reloaded, 1, 0) {
+		span.AddEvent(ctx, "subtree.reloaded")
+	}
+
+	node, _ := w.tree.Load().(Node)
+
+	result, err := node.Tick(ctx)
+
+	recordTick(ctx, span, result, err, tickAttrs("watched", w.name, result))
+
+	return result, err
+}
@@ -0,0 +1,112 @@
+package littlealbert_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"littlealbert"
+)
+
+type fakeSource struct {
+	initial littlealbert.Node
+	changes chan littlealbert.Node
+}
+
+func (s *fakeSource) Load(_ context.Context) (littlealbert.Node, error) {
+	return s.initial, nil
+}
+
+func (s *fakeSource) Watch(_ context.Context) (<-chan littlealbert.Node, error) {
+	return s.changes, nil
+}
+
+func Test_Watched_LoadsInitialSubtree(t *testing.T) {
+	src := &fakeSource{
+		initial: littlealbert.Task("a", func(_ context.Context) (littlealbert.Result, error) {
+			return littlealbert.Success, nil
+		}),
+		changes: make(chan littlealbert.Node),
+	}
+
+	watched := littlealbert.Watched("config", src)
+
+	result, err := watched.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+}
+
+type watchCtxSource struct {
+	initial  littlealbert.Node
+	started  chan struct{}
+	canceled chan struct{}
+}
+
+func (s *watchCtxSource) Load(_ context.Context) (littlealbert.Node, error) {
+	return s.initial, nil
+}
+
+func (s *watchCtxSource) Watch(ctx context.Context) (<-chan littlealbert.Node, error) {
+	go func() {
+		close(s.started)
+		<-ctx.Done()
+		close(s.canceled)
+	}()
+
+	return make(chan littlealbert.Node), nil
+}
+
+func Test_Watched_StopCancelsWatchOnRunCancellation(t *testing.T) {
+	src := &watchCtxSource{
+		initial: littlealbert.Task("a", func(_ context.Context) (littlealbert.Result, error) {
+			return littlealbert.Running, nil
+		}),
+		started:  make(chan struct{}),
+		canceled: make(chan struct{}),
+	}
+
+	tree := littlealbert.Watched("config", src)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go littlealbert.Run(ctx, tree)
+
+	select {
+	case <-src.started:
+	case <-time.After(time.Second):
+		t.Fatal("expected Watch to be started")
+	}
+
+	cancel()
+
+	select {
+	case <-src.canceled:
+	case <-time.After(time.Second):
+		t.Fatal("expected Run's cancellation to stop Watched's watch goroutine")
+	}
+}
+
+func Test_Watched_ReloadsOnChange(t *testing.T) {
+	src := &fakeSource{
+		initial: littlealbert.Task("a", func(_ context.Context) (littlealbert.Result, error) {
+			return littlealbert.Success, nil
+		}),
+		changes: make(chan littlealbert.Node, 1),
+	}
+
+	watched := littlealbert.Watched("config", src)
+
+	result, err := watched.Tick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, littlealbert.Success, result)
+
+	src.changes <- littlealbert.Task("b", func(_ context.Context) (littlealbert.Result, error) {
+		return littlealbert.Failure, nil
+	})
+
+	require.Eventually(t, func() bool {
+		result, err := watched.Tick(context.Background())
+		return err == nil && result == littlealbert.Failure
+	}, time.Second, time.Millisecond)
+}